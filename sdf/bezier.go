@@ -126,6 +126,7 @@ func (p *BezierPolynomial) Set(x []float64) {
 
 type BezierSpline struct {
 	px, py BezierPolynomial // x/y bezier polynomials
+	ctrl   []V2             // original control points (for de Casteljau subdivision)
 }
 
 // Return the function value for a given t value.
@@ -167,11 +168,520 @@ func NewBezierSpline(p []V2) *BezierSpline {
 	}
 	s.px.Set(x)
 	s.py.Set(y)
+	s.ctrl = append([]V2{}, p...)
 	return &s
 }
 
 //-----------------------------------------------------------------------------
 
+// deCasteljau splits a bezier control polygon at parameter t, returning the
+// control points of the left and right subcurves (de Casteljau's algorithm).
+func deCasteljau(ctrl []V2, t float64) ([]V2, []V2) {
+	n := len(ctrl)
+	left := make([]V2, n)
+	right := make([]V2, n)
+	pts := append([]V2{}, ctrl...)
+	left[0] = pts[0]
+	right[n-1] = pts[n-1]
+	for k := 1; k < n; k++ {
+		for i := 0; i < n-k; i++ {
+			pts[i] = pts[i].Add(pts[i+1].Sub(pts[i]).MulScalar(t))
+		}
+		left[k] = pts[0]
+		right[n-1-k] = pts[n-k-1]
+	}
+	return left, right
+}
+
+// isFlat returns true if the control polygon ctrl is flat to within the
+// squared chordal tolerance tol. Flatness is the largest squared
+// perpendicular distance of an interior control point from the chord
+// joining the first and last control points.
+func isFlat(ctrl []V2, tol float64) bool {
+	a := ctrl[0]
+	b := ctrl[len(ctrl)-1]
+	d := b.Sub(a)
+	dd := d.Dot(d)
+	for _, c := range ctrl[1 : len(ctrl)-1] {
+		ac := c.Sub(a)
+		cross := d.X*ac.Y - d.Y*ac.X
+		var distSq float64
+		if dd < POLY_EPSILON {
+			distSq = ac.Dot(ac)
+		} else {
+			distSq = (cross * cross) / dd
+		}
+		if distSq > tol {
+			return false
+		}
+	}
+	return true
+}
+
+// subdivide recursively de Casteljau-splits ctrl until it is flat enough,
+// then appends the leading vertex of the (sub)curve to the polygon p.
+func (s *BezierSpline) subdivide(p *Polygon, ctrl []V2, tol float64, depth int) {
+	if depth <= 0 || isFlat(ctrl, tol) {
+		p.AddV2(ctrl[0])
+		return
+	}
+	left, right := deCasteljau(ctrl, 0.5)
+	s.subdivide(p, left, tol, depth-1)
+	s.subdivide(p, right, tol, depth-1)
+}
+
+//-----------------------------------------------------------------------------
+// Bezier degree conversion.
+
+// QuadraticToCubic losslessly converts a quadratic bezier spline (control
+// points P0, C, P1) to an equivalent cubic bezier spline with control
+// points P0, (P0+2C)/3, (2C+P1)/3, P1.
+func (s *BezierSpline) QuadraticToCubic() *BezierSpline {
+	if s.order() != 2 {
+		panic("QuadraticToCubic requires a quadratic spline")
+	}
+	p0 := s.ctrl[0]
+	c := s.ctrl[1]
+	p1 := s.ctrl[2]
+	c0 := p0.Add(c.MulScalar(2)).MulScalar(1.0 / 3.0)
+	c1 := c.MulScalar(2).Add(p1).MulScalar(1.0 / 3.0)
+	return NewBezierSpline([]V2{p0, c0, c1, p1})
+}
+
+// CubicToQuadratics approximates a cubic bezier spline with a sequence of
+// quadratic bezier splines. The cubic is recursively split with de
+// Casteljau until each resulting quadratic stays within tol of the
+// corresponding cubic sub-curve at several sampled parameter values, an
+// estimate of the Hausdorff distance between the two.
+func (s *BezierSpline) CubicToQuadratics(tol float64) []*BezierSpline {
+	if s.order() != 3 {
+		panic("CubicToQuadratics requires a cubic spline")
+	}
+	return cubicToQuadratics(s.ctrl, tol, defaultMaxDepth)
+}
+
+// cubicToQuadratics recursively approximates the cubic control polygon
+// ctrl with one or more quadratics. The single quadratic's control point
+// is the intersection of the cubic's end tangent legs (P0-P1 and P2-P3).
+func cubicToQuadratics(ctrl []V2, tol float64, depth int) []*BezierSpline {
+	p0, p1, p2, p3 := ctrl[0], ctrl[1], ctrl[2], ctrl[3]
+
+	c, ok := lineIntersect(p0, p1, p2, p3)
+	if !ok {
+		// parallel tangents: fall back to the midpoint of the control legs
+		c = p1.Add(p2).MulScalar(0.5)
+	}
+	quad := NewBezierSpline([]V2{p0, c, p3})
+
+	if depth <= 0 || hausdorffWithin(NewBezierSpline(ctrl), quad, tol) {
+		return []*BezierSpline{quad}
+	}
+	left, right := deCasteljau(ctrl, 0.5)
+	return append(cubicToQuadratics(left, tol, depth-1), cubicToQuadratics(right, tol, depth-1)...)
+}
+
+// hausdorffSamples are the parameter values sampled by hausdorffWithin to
+// estimate the Hausdorff distance between two same-range curves.
+var hausdorffSamples = [...]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9}
+
+// hausdorffWithin reports whether a and b stay within tol of one another
+// across their shared [0,1] domain, estimated by sampling both curves at
+// several parameter values and taking the largest separation. This bounds
+// the per-segment Hausdorff error of a cubic-to-quadratic approximation,
+// unlike a single-point (e.g. midpoint-only) comparison.
+func hausdorffWithin(a, b *BezierSpline, tol float64) bool {
+	tolSq := tol * tol
+	for _, t := range hausdorffSamples {
+		d := a.f0(t).Sub(b.f0(t))
+		if d.Dot(d) > tolSq {
+			return false
+		}
+	}
+	return true
+}
+
+// lineIntersect returns the intersection of line a0-a1 with line b0-b1,
+// and false if the lines are parallel (or near-parallel).
+func lineIntersect(a0, a1, b0, b1 V2) (V2, bool) {
+	d1 := a1.Sub(a0)
+	d2 := b1.Sub(b0)
+	denom := d1.X*d2.Y - d1.Y*d2.X
+	if Abs(denom) < POLY_EPSILON {
+		return V2{}, false
+	}
+	diff := b0.Sub(a0)
+	t := (diff.X*d2.Y - diff.Y*d2.X) / denom
+	return a0.Add(d1.MulScalar(t)), true
+}
+
+//-----------------------------------------------------------------------------
+// Curve splitting, joining and measurement.
+
+// Split splits the spline at parameter t using de Casteljau's algorithm,
+// returning the curves for [0,t] and [t,1].
+func (s *BezierSpline) Split(t float64) (*BezierSpline, *BezierSpline) {
+	left, right := deCasteljau(s.ctrl, t)
+	return NewBezierSpline(left), NewBezierSpline(right)
+}
+
+// SubSpline returns the portion of the curve between parameters t0 and t1.
+func (s *BezierSpline) SubSpline(t0, t1 float64) *BezierSpline {
+	if t0 > t1 {
+		t0, t1 = t1, t0
+	}
+	_, right := s.Split(t0)
+	// re-map t1 into the parameter space of the [t0,1] sub-curve
+	t1 = (t1 - t0) / (1 - t0)
+	left, _ := right.Split(t1)
+	return left
+}
+
+// Reverse reverses the direction of the spline in place, so that it runs
+// from its old end point to its old start point.
+func (s *BezierSpline) Reverse() {
+	n := len(s.ctrl)
+	ctrl := make([]V2, n)
+	for i, v := range s.ctrl {
+		ctrl[n-1-i] = v
+	}
+	*s = *NewBezierSpline(ctrl)
+}
+
+// Extend returns a new spline with its far end point moved to newEnd,
+// preserving the exit tangent vector at that end point.
+func (s *BezierSpline) Extend(newEnd V2) *BezierSpline {
+	n := len(s.ctrl)
+	ctrl := append([]V2{}, s.ctrl...)
+	tangent := ctrl[n-1].Sub(ctrl[n-2])
+	ctrl[n-1] = newEnd
+	ctrl[n-2] = newEnd.Sub(tangent)
+	return NewBezierSpline(ctrl)
+}
+
+// gaussLegendre5 nodes and weights for 5-point Gauss-Legendre quadrature
+// on the interval [-1,1].
+var gl5Nodes = [5]float64{0, 0.5384693101056831, -0.5384693101056831, 0.9061798459386640, -0.9061798459386640}
+var gl5Weights = [5]float64{0.5688888888888889, 0.4786286704993665, 0.4786286704993665, 0.2369268850561891, 0.2369268850561891}
+
+// gaussLegendre5 integrates f over [a,b] with a 5-point Gauss-Legendre rule.
+func gaussLegendre5(f func(float64) float64, a, b float64) float64 {
+	mid := 0.5 * (a + b)
+	halfLen := 0.5 * (b - a)
+	sum := 0.0
+	for i, x := range gl5Nodes {
+		sum += gl5Weights[i] * f(mid+halfLen*x)
+	}
+	return sum * halfLen
+}
+
+// adaptiveLength recursively halves [a,b] until the 5-point Gauss-Legendre
+// estimate over the whole interval agrees with the sum of the estimates
+// over its two halves to within tol, or depth is exhausted.
+func adaptiveLength(f func(float64) float64, a, b, tol float64, depth int) float64 {
+	whole := gaussLegendre5(f, a, b)
+	mid := 0.5 * (a + b)
+	left := gaussLegendre5(f, a, mid)
+	right := gaussLegendre5(f, mid, b)
+	if depth <= 0 || Abs(left+right-whole) < tol {
+		return left + right
+	}
+	return adaptiveLength(f, a, mid, tol, depth-1) + adaptiveLength(f, mid, b, tol, depth-1)
+}
+
+// Length returns the arc length of the curve, computed by adaptive
+// Gauss-Legendre quadrature of the curve's speed |s'(t)| over t in [0,1],
+// recursively halving the interval until successive estimates agree to
+// within tol.
+func (s *BezierSpline) Length(tol float64) float64 {
+	speed := func(t float64) float64 {
+		x1 := s.px.f1(t)
+		y1 := s.py.f1(t)
+		return math.Sqrt(x1*x1 + y1*y1)
+	}
+	return adaptiveLength(speed, 0, 1, tol, defaultMaxDepth)
+}
+
+//-----------------------------------------------------------------------------
+// Intersection of bezier curves with lines and with other bezier curves.
+
+// defaultLineTolerance is the default convergence tolerance used by
+// IntersectLine and IntersectSpline. It is a distance in curve units,
+// and IntersectSpline also uses it as the parameter interval width at
+// which clipping stops.
+const defaultLineTolerance = 1e-6
+
+// IntersectLine returns the parameter values t in [0,1] at which the curve
+// crosses the (infinite) line through a and b. The control polygon is
+// recursively de Casteljau-subdivided, discarding sub-curves whose
+// control points don't straddle the line, until a crossing sub-curve is
+// flat enough to report its root by linear interpolation.
+func (s *BezierSpline) IntersectLine(a, b V2) []float64 {
+	return intersectLine(s.ctrl, a, b, 0, 1, defaultLineTolerance, defaultMaxDepth)
+}
+
+func intersectLine(ctrl []V2, a, b V2, t0, t1, tol float64, depth int) []float64 {
+	d := b.Sub(a)
+	dist := make([]float64, len(ctrl))
+	sign := 0
+	mixed := false
+	for i, c := range ctrl {
+		ac := c.Sub(a)
+		dist[i] = d.X*ac.Y - d.Y*ac.X
+		switch {
+		case dist[i] > POLY_EPSILON:
+			if sign == 0 {
+				sign = 1
+			} else if sign < 0 {
+				mixed = true
+			}
+		case dist[i] < -POLY_EPSILON:
+			if sign == 0 {
+				sign = -1
+			} else if sign > 0 {
+				mixed = true
+			}
+		}
+	}
+	if !mixed {
+		// the control polygon (and hence the curve, by the convex hull
+		// property) doesn't straddle the line
+		return nil
+	}
+	if depth <= 0 || isFlat(ctrl, tol*tol) {
+		d0, d1 := dist[0], dist[len(dist)-1]
+		if d0 == d1 {
+			return nil
+		}
+		frac := d0 / (d0 - d1)
+		return []float64{t0 + frac*(t1-t0)}
+	}
+	left, right := deCasteljau(ctrl, 0.5)
+	mid := 0.5 * (t0 + t1)
+	roots := intersectLine(left, a, b, t0, mid, tol, depth-1)
+	return append(roots, intersectLine(right, a, b, mid, t1, tol, depth-1)...)
+}
+
+// BezierIntersection is a single curve-curve crossing: T1 is the
+// parameter on the receiver curve and T2 is the parameter on the other
+// curve passed to IntersectSpline.
+type BezierIntersection struct {
+	T1, T2 float64
+}
+
+// IntersectSpline returns the parameter pairs at which the receiver and
+// other cross. It uses recursive fat-line clipping (Sederberg-Nishita):
+// at each step, the fat line of one curve's control polygon clips the
+// other curve's parameter interval down to the sub-range that can
+// possibly cross it, the curves swap roles, and the process repeats
+// until both intervals have converged to within tol. When a clip fails
+// to shrink the interval (a curve can cross a fat line several times),
+// the curve being clipped is split in half and both halves are pursued,
+// so curves that cross more than once are all found.
+func (s *BezierSpline) IntersectSpline(other *BezierSpline) []BezierIntersection {
+	hits := clipSplines(s.ctrl, other.ctrl, 0, 1, 0, 1, false, defaultLineTolerance, defaultMaxDepth*2)
+	return dedupeIntersections(hits, defaultLineTolerance)
+}
+
+// clipSplines clips a against b's fat line (or vice versa) and recurses.
+// swapped records whether a is currently the curve passed to
+// IntersectSpline as other (true) or as the receiver (false), so that a
+// terminal hit can always be reported as (receiver, other) regardless of
+// how many times the roles have swapped.
+func clipSplines(a, b []V2, a0, a1, b0, b1 float64, swapped bool, tol float64, iter int) []BezierIntersection {
+	if !boxesOverlap(a, b, tol) {
+		return nil
+	}
+	if iter <= 0 || (a1-a0 < tol && b1-b0 < tol) {
+		if swapped {
+			return []BezierIntersection{{0.5 * (b0 + b1), 0.5 * (a0 + a1)}}
+		}
+		return []BezierIntersection{{0.5 * (a0 + a1), 0.5 * (b0 + b1)}}
+	}
+	lo, hi, ok := fatLineClip(a, b, tol)
+	if !ok {
+		return nil
+	}
+	if hi-lo > 0.8 {
+		// the clip barely shrank the interval: b may cross a's fat line
+		// more than once, so split b and pursue both halves instead
+		leftB, rightB := deCasteljau(b, 0.5)
+		bMid := 0.5 * (b0 + b1)
+		var hits []BezierIntersection
+		hits = append(hits, clipSplines(leftB, a, b0, bMid, a0, a1, !swapped, tol, iter-1)...)
+		hits = append(hits, clipSplines(rightB, a, bMid, b1, a0, a1, !swapped, tol, iter-1)...)
+		return hits
+	}
+	nb0 := b0 + lo*(b1-b0)
+	nb1 := b0 + hi*(b1-b0)
+	clippedB := subControlPoints(b, lo, hi)
+	// swap curves so the next pass clips `a` against the (now clipped) `b`
+	return clipSplines(clippedB, a, nb0, nb1, a0, a1, !swapped, tol, iter-1)
+}
+
+// dedupeIntersections merges hits that are within tol of one another in
+// both parameters, which recursive splitting can otherwise report more
+// than once for the same crossing.
+func dedupeIntersections(hits []BezierIntersection, tol float64) []BezierIntersection {
+	var out []BezierIntersection
+	for _, h := range hits {
+		dup := false
+		for _, o := range out {
+			if Abs(h.T1-o.T1) < 10*tol && Abs(h.T2-o.T2) < 10*tol {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// fatLineClip returns the sub-range [lo,hi] of b's parameter domain that
+// can possibly cross a's fat line: the band around a's baseline (the
+// chord from its first to last control point) bounded by the
+// perpendicular extent of a's own control points. b is plotted as the
+// points (t_i, signedDistance(B_i)); the convex hull of those points is
+// intersected with the band to find [lo,hi], since the curve itself lies
+// within that hull. ok is false if the hull never enters the band.
+func fatLineClip(a, b []V2, tol float64) (lo, hi float64, ok bool) {
+	p0, p1 := a[0], a[len(a)-1]
+	d := p1.Sub(p0)
+	dd := d.Dot(d)
+	dist := func(c V2) float64 {
+		ac := c.Sub(p0)
+		if dd < POLY_EPSILON {
+			return math.Sqrt(ac.Dot(ac))
+		}
+		return (d.X*ac.Y - d.Y*ac.X) / math.Sqrt(dd)
+	}
+	dmin, dmax := 0.0, 0.0
+	for _, c := range a {
+		dc := dist(c)
+		dmin = math.Min(dmin, dc)
+		dmax = math.Max(dmax, dc)
+	}
+
+	m := len(b) - 1
+	pts := make([]hullPoint, len(b))
+	for i, c := range b {
+		pts[i] = hullPoint{t: float64(i) / float64(m), d: dist(c)}
+	}
+	upper, lower := hullChains(pts)
+
+	consider := func(t float64) {
+		if !ok || t < lo {
+			lo = t
+		}
+		if !ok || t > hi {
+			hi = t
+		}
+		ok = true
+	}
+	clipChain := func(chain []hullPoint) {
+		for i, p := range chain {
+			if p.d >= dmin-tol && p.d <= dmax+tol {
+				consider(p.t)
+			}
+			if i == 0 {
+				continue
+			}
+			q := chain[i-1]
+			if t, crosses := edgeCrossing(q, p, dmin); crosses {
+				consider(t)
+			}
+			if t, crosses := edgeCrossing(q, p, dmax); crosses {
+				consider(t)
+			}
+		}
+	}
+	lo, hi = 1, 0
+	clipChain(upper)
+	clipChain(lower)
+	return lo, hi, ok
+}
+
+// hullPoint is a control point of one curve plotted against the signed
+// distance to the other curve's baseline, for fat-line clipping.
+type hullPoint struct {
+	t, d float64
+}
+
+// hullChains returns the upper and lower convex hull chains of pts, which
+// must already be sorted by strictly increasing t (Andrew's monotone
+// chain, specialized since no t-sort is needed).
+func hullChains(pts []hullPoint) (upper, lower []hullPoint) {
+	for _, p := range pts {
+		for len(lower) >= 2 && hullCross(lower[len(lower)-2], lower[len(lower)-1], p) <= 0 {
+			lower = lower[:len(lower)-1]
+		}
+		lower = append(lower, p)
+	}
+	for i := len(pts) - 1; i >= 0; i-- {
+		p := pts[i]
+		for len(upper) >= 2 && hullCross(upper[len(upper)-2], upper[len(upper)-1], p) <= 0 {
+			upper = upper[:len(upper)-1]
+		}
+		upper = append(upper, p)
+	}
+	return upper, lower
+}
+
+// hullCross is the z-component of (a-o) x (b-o), used to test turn
+// direction while building a convex hull chain.
+func hullCross(o, a, b hullPoint) float64 {
+	return (a.t-o.t)*(b.d-o.d) - (a.d-o.d)*(b.t-o.t)
+}
+
+// edgeCrossing returns the t at which the segment from q to p crosses
+// d == level, if the segment straddles that level.
+func edgeCrossing(q, p hullPoint, level float64) (t float64, crosses bool) {
+	if (q.d-level)*(p.d-level) > 0 || p.d == q.d {
+		return 0, false
+	}
+	frac := (level - q.d) / (p.d - q.d)
+	return q.t + frac*(p.t-q.t), true
+}
+
+// subControlPoints returns the control polygon of the curve restricted to
+// the parameter sub-range [lo,hi] of ctrl's own [0,1] domain.
+func subControlPoints(ctrl []V2, lo, hi float64) []V2 {
+	if lo <= 0 {
+		left, _ := deCasteljau(ctrl, hi)
+		return left
+	}
+	_, right := deCasteljau(ctrl, lo)
+	if hi >= 1 {
+		return right
+	}
+	left, _ := deCasteljau(right, (hi-lo)/(1-lo))
+	return left
+}
+
+// boxesOverlap returns true if the axis-aligned bounding boxes of the two
+// control polygons overlap, within tol.
+func boxesOverlap(a, b []V2, tol float64) bool {
+	aMinX, aMinY, aMaxX, aMaxY := bbox(a)
+	bMinX, bMinY, bMaxX, bMaxY := bbox(b)
+	return aMinX-tol <= bMaxX && bMinX-tol <= aMaxX && aMinY-tol <= bMaxY && bMinY-tol <= aMaxY
+}
+
+// bbox returns the axis-aligned bounding box of a control polygon.
+func bbox(ctrl []V2) (minX, minY, maxX, maxY float64) {
+	minX, minY = ctrl[0].X, ctrl[0].Y
+	maxX, maxY = minX, minY
+	for _, c := range ctrl[1:] {
+		minX = math.Min(minX, c.X)
+		minY = math.Min(minY, c.Y)
+		maxX = math.Max(maxX, c.X)
+		maxY = math.Max(maxY, c.Y)
+	}
+	return
+}
+
+//-----------------------------------------------------------------------------
+
 type BezierVertexType int
 
 const (
@@ -186,9 +696,18 @@ type BezierVertex struct {
 	handle_rev V2               // polar coordinates of reverse handle
 }
 
+// Default flatness tolerance (squared) and recursion depth for adaptive
+// de Casteljau subdivision of a bezier curve into a polygon.
+const (
+	defaultChordalTolerance = 0.01
+	defaultMaxDepth         = 16
+)
+
 type Bezier struct {
-	closed bool           // is the curve closed or open?
-	vlist  []BezierVertex // list of bezier vertices
+	closed    bool           // is the curve closed or open?
+	vlist     []BezierVertex // list of bezier vertices
+	tolerance float64        // squared chordal flatness tolerance, 0 == use default
+	maxDepth  int            // maximum de Casteljau recursion depth, 0 == use default
 }
 
 //-----------------------------------------------------------------------------
@@ -289,11 +808,88 @@ func NewBezier() *Bezier {
 	return &Bezier{}
 }
 
+// NewBezierThroughPoints builds a Bezier curve that interpolates
+// (passes through) each of the given points, rather than treating them as
+// control handles. Three points are fit exactly with a single quadratic
+// segment. Four or more points are chord-length parameterized and fit
+// with a C1-continuous piecewise cubic, one segment per adjacent pair.
+func NewBezierThroughPoints(pts []V2) *Bezier {
+	n := len(pts)
+	if n < 2 {
+		panic("need at least two points to fit a bezier curve")
+	}
+	b := NewBezier()
+	switch {
+	case n == 2:
+		b.AddV2(pts[0])
+		b.AddV2(pts[1])
+	case n == 3:
+		// the control point that places pts[1] at t=0.5 on a quadratic
+		// bezier lies on the line from the midpoint of the chord
+		// pts[0]-pts[2] through pts[1], as far beyond pts[1] as pts[1] is
+		// from that midpoint.
+		c := pts[1].MulScalar(2).Sub(pts[0].Add(pts[2]).MulScalar(0.5))
+		b.AddV2(pts[0])
+		b.AddV2(c).Mid()
+		b.AddV2(pts[2])
+	default:
+		fitChordLength(b, pts)
+	}
+	return b
+}
+
+// fitChordLength adds endpoints and handles to b so that it interpolates
+// pts with a C1-continuous piecewise cubic. The tangent at each interior
+// point is the chord-length-weighted Catmull-Rom difference of its
+// neighbours; handle lengths are a third of the adjacent segment's chord
+// length, the standard Catmull-Rom to bezier conversion. This is a local
+// tangent heuristic rather than a global per-segment least-squares fit:
+// it interpolates every point with C1 joints, but does not minimize any
+// least-squares error term between segments.
+func fitChordLength(b *Bezier, pts []V2) {
+	n := len(pts)
+	d := make([]float64, n-1)
+	for i := range d {
+		d[i] = pts[i+1].Sub(pts[i]).Length()
+	}
+	tangent := make([]V2, n)
+	tangent[0] = pts[1].Sub(pts[0])
+	tangent[n-1] = pts[n-1].Sub(pts[n-2])
+	for i := 1; i < n-1; i++ {
+		tangent[i] = pts[i+1].Sub(pts[i-1]).MulScalar(1 / (d[i-1] + d[i]))
+	}
+
+	theta0 := math.Atan2(tangent[0].Y, tangent[0].X)
+	b.AddV2(pts[0]).HandleFwd(theta0, d[0]/3)
+
+	for i := 1; i < n-1; i++ {
+		theta := math.Atan2(tangent[i].Y, tangent[i].X)
+		b.AddV2(pts[i]).Handle(theta, d[i]/3, d[i-1]/3)
+	}
+
+	thetaN := math.Atan2(tangent[n-1].Y, tangent[n-1].X)
+	b.AddV2(pts[n-1]).HandleRev(thetaN+PI, d[n-2]/3)
+}
+
 // Close the bezier curve.
 func (b *Bezier) Close() {
 	b.closed = true
 }
 
+// SetTolerance sets the squared chordal flatness tolerance used to control
+// adaptive subdivision of the curve into polygon edges. Smaller values give
+// a denser polygon with more closely-fitted curvature.
+func (b *Bezier) SetTolerance(chordal float64) {
+	b.tolerance = chordal
+}
+
+// SetMaxDepth sets the maximum de Casteljau recursion depth used when
+// flattening the curve into a polygon. This bounds the work done on
+// pathological curves (e.g. cusps) that never become flat.
+func (b *Bezier) SetMaxDepth(depth int) {
+	b.maxDepth = depth
+}
+
 // Add a V2 vertex to a polygon.
 func (b *Bezier) AddV2(x V2) *BezierVertex {
 	v := BezierVertex{}
@@ -388,29 +984,24 @@ func (b *Bezier) Polygon() *Polygon {
 
 	// render the splines to a polygon
 	p := NewPolygon()
-	k := 1000
-	dtmin := 1.0 / float64(k-1)
-	epsilon := 0.1
+	tol := b.tolerance
+	if tol == 0 {
+		tol = defaultChordalTolerance
+	}
+	depth := b.maxDepth
+	if depth == 0 {
+		depth = defaultMaxDepth
+	}
 
 	for _, s := range splines {
-
 		if s.order() == 1 {
 			// linear
 			p.AddV2(s.f0(0))
 			p.AddV2(s.f0(1))
 		} else {
-			t := 0.0
-			for t < 1.0 {
-				p.AddV2(s.f0(t))
-				dtheta := Abs(s.slope(t+dtmin) - s.slope(t))
-				if dtheta < epsilon {
-					t += dtmin * (epsilon / dtheta)
-				} else {
-					t += dtmin
-				}
-			}
-			p.AddV2(s.f0(1))
-
+			// adaptively subdivide with de Casteljau until flat enough
+			s.subdivide(p, s.ctrl, tol, depth)
+			p.AddV2(s.ctrl[len(s.ctrl)-1])
 		}
 	}
 	return p
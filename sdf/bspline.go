@@ -0,0 +1,232 @@
+//-----------------------------------------------------------------------------
+/*
+
+Create curves using B-spline (NURBS-style, non-rational) curves.
+
+A B-spline generalizes a Bezier curve: it has an arbitrary degree
+independent of the number of control points, and a knot vector that
+controls how the control points influence the curve locally. This
+sits alongside the Bezier curve support in bezier.go: a B-spline can be
+converted to Bezier splines via BSpline.ToBezierSegments, and those can
+be converted between quadratic and cubic form via
+BezierSpline.QuadraticToCubic/CubicToQuadratics. There is no conversion
+from Bezier splines to a B-spline.
+
+*/
+//-----------------------------------------------------------------------------
+
+package sdf
+
+import (
+	"fmt"
+)
+
+//-----------------------------------------------------------------------------
+
+// BSpline is a B-spline curve of arbitrary degree, defined by a control
+// polygon and a knot vector, evaluated via the de Boor recurrence.
+type BSpline struct {
+	degree int       // curve degree
+	ctrl   []V2      // control points
+	knots  []float64 // knot vector, len(ctrl)+degree+1 entries
+}
+
+// NewBSpline returns a B-spline curve of the given degree with the
+// supplied control points and knot vector.
+func NewBSpline(degree int, ctrl []V2, knots []float64) *BSpline {
+	if degree < 1 {
+		panic("degree must be >= 1")
+	}
+	if len(ctrl) < degree+1 {
+		panic("need at least degree+1 control points")
+	}
+	if len(knots) != len(ctrl)+degree+1 {
+		panic(fmt.Sprintf("knot vector must have %d entries, got %d", len(ctrl)+degree+1, len(knots)))
+	}
+	return &BSpline{
+		degree: degree,
+		ctrl:   append([]V2{}, ctrl...),
+		knots:  append([]float64{}, knots...),
+	}
+}
+
+// NewBSplineUniform returns a B-spline curve of the given degree with the
+// supplied control points and a clamped, uniformly-spaced knot vector (the
+// curve interpolates its first and last control points).
+func NewBSplineUniform(degree int, ctrl []V2) *BSpline {
+	n := len(ctrl)
+	if n < degree+1 {
+		panic("need at least degree+1 control points")
+	}
+	nSpans := n - degree
+	knots := make([]float64, n+degree+1)
+	for i := 0; i <= degree; i++ {
+		knots[i] = 0
+		knots[len(knots)-1-i] = float64(nSpans)
+	}
+	for i := 1; i < nSpans; i++ {
+		knots[degree+i] = float64(i)
+	}
+	return NewBSpline(degree, ctrl, knots)
+}
+
+//-----------------------------------------------------------------------------
+
+// findSpan returns the knot span index k such that knots[k] <= u <
+// knots[k+1] (or the last valid span, if u is at or beyond the curve's
+// final knot value).
+func (s *BSpline) findSpan(u float64) int {
+	n := len(s.ctrl) - 1
+	p := s.degree
+	if u >= s.knots[n+1] {
+		return n
+	}
+	if u <= s.knots[p] {
+		return p
+	}
+	lo, hi := p, n+1
+	for {
+		mid := (lo + hi) / 2
+		switch {
+		case u < s.knots[mid]:
+			hi = mid
+		case u >= s.knots[mid+1]:
+			lo = mid
+		default:
+			return mid
+		}
+	}
+}
+
+// f0 evaluates the curve at parameter u using the de Boor recurrence.
+func (s *BSpline) f0(u float64) V2 {
+	p := s.degree
+	k := s.findSpan(u)
+	d := make([]V2, p+1)
+	for j := 0; j <= p; j++ {
+		d[j] = s.ctrl[k-p+j]
+	}
+	for r := 1; r <= p; r++ {
+		for j := p; j >= r; j-- {
+			i := k - p + j
+			alpha := 0.0
+			if denom := s.knots[i+p-r+1] - s.knots[i]; denom != 0 {
+				alpha = (u - s.knots[i]) / denom
+			}
+			d[j] = d[j-1].MulScalar(1 - alpha).Add(d[j].MulScalar(alpha))
+		}
+	}
+	return d[p]
+}
+
+// Polygon returns a polygon approximation of the B-spline curve, sampling
+// the curve uniformly across its parameter domain.
+func (s *BSpline) Polygon() *Polygon {
+	p := NewPolygon()
+	n := len(s.ctrl) - 1
+	u0 := s.knots[s.degree]
+	u1 := s.knots[n+1]
+	const steps = 200
+	for i := 0; i <= steps; i++ {
+		u := u0 + (u1-u0)*float64(i)/float64(steps)
+		p.AddV2(s.f0(u))
+	}
+	return p
+}
+
+//-----------------------------------------------------------------------------
+
+// InsertKnot inserts the knot u once, using Boehm's algorithm. This
+// increases the knot's multiplicity and adds one control point, without
+// changing the shape of the curve.
+func (s *BSpline) InsertKnot(u float64) {
+	p := s.degree
+	k := s.findSpan(u)
+	for k+1 < len(s.knots) && s.knots[k+1] == u {
+		k++
+	}
+
+	newCtrl := make([]V2, len(s.ctrl)+1)
+	copy(newCtrl[:k-p+1], s.ctrl[:k-p+1])
+	for i := k - p + 1; i <= k; i++ {
+		alpha := 0.0
+		if denom := s.knots[i+p] - s.knots[i]; denom != 0 {
+			alpha = (u - s.knots[i]) / denom
+		}
+		newCtrl[i] = s.ctrl[i-1].MulScalar(1 - alpha).Add(s.ctrl[i].MulScalar(alpha))
+	}
+	copy(newCtrl[k+1:], s.ctrl[k:])
+
+	newKnots := make([]float64, len(s.knots)+1)
+	copy(newKnots[:k+1], s.knots[:k+1])
+	newKnots[k+1] = u
+	copy(newKnots[k+2:], s.knots[k+1:])
+
+	s.ctrl = newCtrl
+	s.knots = newKnots
+}
+
+// ToBezierSegments decomposes the B-spline into a sequence of Bezier
+// splines of the same degree, by repeatedly inserting each interior knot
+// (Boehm's algorithm) until it reaches full multiplicity. Adjacent
+// segments share their boundary control point, matching the curve's
+// original C0 continuity there.
+//
+// Only interior knots are inserted, so the knot vector is taken to be
+// clamped (as NewBSplineUniform makes it). For an unclamped knot vector
+// the first and last segments do not in general match the curve.
+//
+// BezierSpline only supports linear through quartic curves (degree 1-4),
+// so this method panics if the B-spline's degree is higher than that. Use
+// InsertKnot directly (or evaluate the B-spline itself) for higher
+// degrees.
+func (s *BSpline) ToBezierSegments() []*BezierSpline {
+	if s.degree > 4 {
+		panic(fmt.Sprintf("ToBezierSegments only supports degree <= 4, got %d", s.degree))
+	}
+	b := &BSpline{
+		degree: s.degree,
+		ctrl:   append([]V2{}, s.ctrl...),
+		knots:  append([]float64{}, s.knots...),
+	}
+	p := b.degree
+
+	for {
+		u, mult, ok := nextInteriorKnot(b.knots, p)
+		if !ok {
+			break
+		}
+		for m := mult; m < p; m++ {
+			b.InsertKnot(u)
+		}
+	}
+
+	var segs []*BezierSpline
+	n := len(b.ctrl)
+	for i := 0; i+p < n; i += p {
+		segs = append(segs, NewBezierSpline(append([]V2{}, b.ctrl[i:i+p+1]...)))
+	}
+	return segs
+}
+
+// nextInteriorKnot returns the next distinct interior knot value (and its
+// current multiplicity) that has not yet reached multiplicity degree.
+func nextInteriorKnot(knots []float64, degree int) (u float64, mult int, ok bool) {
+	i := degree + 1
+	for i < len(knots)-degree-1 {
+		u = knots[i]
+		mult = 1
+		j := i + 1
+		for j < len(knots) && knots[j] == u {
+			mult++
+			j++
+		}
+		if mult < degree {
+			return u, mult, true
+		}
+		i = j
+	}
+	return 0, 0, false
+}
+
+//-----------------------------------------------------------------------------